@@ -0,0 +1,234 @@
+// Package analyzer implements the listfunc go/analysis pass: it reports
+// every exported top-level function and method declaration found in the
+// analyzed packages, and exports a FuncFact describing each one's
+// signature for consumption by downstream analyzers.
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzer reports each exported function or method declaration found in
+// the analyzed packages.
+var Analyzer = &analysis.Analyzer{
+	Name:      "listfunc",
+	Doc:       "reports exported function and method declarations",
+	Run:       run,
+	FactTypes: []analysis.Fact{new(FuncFact)},
+}
+
+// FuncFact carries the receiver, parameters, and results of an exported
+// function or method, so that downstream analyzers (or -json consumers)
+// can recover the signature without re-parsing the AST.
+type FuncFact struct {
+	Receiver string
+	Params   string
+	Results  string
+}
+
+// AFact marks FuncFact as an analysis.Fact.
+func (f *FuncFact) AFact() {}
+
+func (f *FuncFact) String() string {
+	return fmt.Sprintf("receiver=%q params=%q results=%q", f.Receiver, f.Params, f.Results)
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	for _, file := range pass.Files {
+		for _, xdecl := range file.Decls {
+			decl, ok := xdecl.(*ast.FuncDecl)
+			if !ok || !Exported(decl) {
+				continue
+			}
+			pass.Reportf(decl.Pos(), "%s", FormatFuncDecl(decl))
+			if obj := pass.TypesInfo.ObjectOf(decl.Name); obj != nil {
+				pass.ExportObjectFact(obj, &FuncFact{
+					Receiver: receiverType(decl),
+					Params:   FormatFuncParams(decl.Type.Params),
+					Results:  FormatFuncResults(decl.Type.Results),
+				})
+			}
+		}
+	}
+	return nil, nil
+}
+
+func receiverType(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) != 1 {
+		return ""
+	}
+	return FormatType(decl.Recv.List[0].Type)
+}
+
+// Exported reports whether decl is an exported top-level function, or a
+// method with both an exported receiver type and an exported name.
+func Exported(decl *ast.FuncDecl) bool {
+	isUpper0 := func(s string) bool {
+		if strings.HasPrefix(s, "*") {
+			return unicode.IsUpper([]rune(s)[1])
+		}
+		return unicode.IsUpper([]rune(s)[0])
+	}
+	if decl.Recv != nil {
+		if len(decl.Recv.List) != 1 {
+			panic(fmt.Errorf("strange receiver for %s: %#v", decl.Name.Name, decl.Recv))
+		}
+		field := decl.Recv.List[0]
+		return isUpper0(FormatType(field.Type)) && isUpper0(decl.Name.Name)
+	}
+	return isUpper0(decl.Name.Name)
+}
+
+// FormatType renders typ as Go source syntax.
+func FormatType(typ ast.Expr) string {
+	switch t := typ.(type) {
+	case nil:
+		return ""
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return fmt.Sprintf("%s.%s", FormatType(t.X), t.Sel.Name)
+	case *ast.StarExpr:
+		return fmt.Sprintf("*%s", FormatType(t.X))
+	case *ast.ArrayType:
+		return fmt.Sprintf("[%s]%s", FormatType(t.Len), FormatType(t.Elt))
+	case *ast.Ellipsis:
+		return "..." + FormatType(t.Elt)
+	case *ast.FuncType:
+		return fmt.Sprintf("func(%s)%s", FormatFuncParams(t.Params), FormatFuncResults(t.Results))
+	case *ast.MapType:
+		return fmt.Sprintf("map[%s]%s", FormatType(t.Key), FormatType(t.Value))
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.SEND:
+			return fmt.Sprintf("chan<- %s", FormatType(t.Value))
+		case ast.RECV:
+			return fmt.Sprintf("<-chan %s", FormatType(t.Value))
+		default:
+			return fmt.Sprintf("chan %s", FormatType(t.Value))
+		}
+	case *ast.InterfaceType:
+		return formatInterfaceType(t)
+	case *ast.StructType:
+		return formatStructType(t)
+	case *ast.IndexExpr:
+		return fmt.Sprintf("%s[%s]", FormatType(t.X), FormatType(t.Index))
+	case *ast.IndexListExpr:
+		indices := make([]string, len(t.Indices))
+		for i, idx := range t.Indices {
+			indices[i] = FormatType(idx)
+		}
+		return fmt.Sprintf("%s[%s]", FormatType(t.X), strings.Join(indices, ", "))
+	case *ast.BasicLit:
+		return t.Value
+	default:
+		panic(fmt.Errorf("unsupported type %#v", t))
+	}
+}
+
+// formatInterfaceType renders an interface type literal, e.g.
+// "interface{}" or "interface { Read(p []byte) (int, error) }".
+func formatInterfaceType(t *ast.InterfaceType) string {
+	if t.Methods == nil || len(t.Methods.List) == 0 {
+		return "interface{}"
+	}
+	var methods []string
+	for _, field := range t.Methods.List {
+		if len(field.Names) == 0 {
+			// embedded interface
+			methods = append(methods, FormatType(field.Type))
+			continue
+		}
+		ft, ok := field.Type.(*ast.FuncType)
+		for _, name := range field.Names {
+			if !ok {
+				methods = append(methods, name.Name)
+				continue
+			}
+			methods = append(methods, fmt.Sprintf("%s(%s)%s", name.Name, FormatFuncParams(ft.Params), FormatFuncResults(ft.Results)))
+		}
+	}
+	return fmt.Sprintf("interface { %s }", strings.Join(methods, "; "))
+}
+
+// formatStructType renders a struct type literal, e.g. "struct{}" or
+// "struct { X int }".
+func formatStructType(t *ast.StructType) string {
+	if t.Fields == nil || len(t.Fields.List) == 0 {
+		return "struct{}"
+	}
+	return fmt.Sprintf("struct { %s }", FormatFields(t.Fields))
+}
+
+// FormatFields renders a field list (parameters or results) as Go source
+// syntax.
+func FormatFields(fields *ast.FieldList) string {
+	s := ""
+	for i, field := range fields.List {
+		for j, name := range field.Names {
+			s += name.Name
+			if j != len(field.Names)-1 {
+				s += ","
+			}
+			s += " "
+		}
+		s += FormatType(field.Type)
+		if i != len(fields.List)-1 {
+			s += ", "
+		}
+	}
+	return s
+}
+
+// FormatFuncParams renders a function's parameter list.
+func FormatFuncParams(fields *ast.FieldList) string {
+	return FormatFields(fields)
+}
+
+// FormatFuncResults renders a function's result list, parenthesized when
+// there is more than one result.
+func FormatFuncResults(fields *ast.FieldList) string {
+	s := ""
+	if fields != nil {
+		s += " "
+		if len(fields.List) > 1 {
+			s += "("
+		}
+		s += FormatFields(fields)
+		if len(fields.List) > 1 {
+			s += ")"
+		}
+	}
+	return s
+}
+
+// FormatFuncDecl renders decl's full signature as Go source syntax. It
+// returns "" for a method whose receiver is unnamed, e.g. "func (T) M()".
+func FormatFuncDecl(decl *ast.FuncDecl) string {
+	s := "func "
+	if decl.Recv != nil {
+		if len(decl.Recv.List) != 1 {
+			panic(fmt.Errorf("strange receiver for %s: %#v", decl.Name.Name, decl.Recv))
+		}
+		field := decl.Recv.List[0]
+		if len(field.Names) == 0 {
+			// unnamed receiver (ignore)
+			return ""
+		} else if len(field.Names) != 1 {
+			panic(fmt.Errorf("strange receiver field for %s: %#v", decl.Name.Name, field))
+		}
+		s += fmt.Sprintf("(%s %s) ", field.Names[0], FormatType(field.Type))
+	}
+	s += decl.Name.Name
+	if tparams := decl.Type.TypeParams; tparams != nil && len(tparams.List) > 0 {
+		s += fmt.Sprintf("[%s]", FormatFields(tparams))
+	}
+	s += fmt.Sprintf("(%s)", FormatFuncParams(decl.Type.Params))
+	s += FormatFuncResults(decl.Type.Results)
+	return s
+}
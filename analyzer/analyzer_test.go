@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func parseFuncDecl(t *testing.T, src string) *ast.FuncDecl {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", "package p\n"+src, 0)
+	if err != nil {
+		t.Fatalf("parse %q: %v", src, err)
+	}
+	for _, decl := range file.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok {
+			return fd
+		}
+	}
+	t.Fatalf("no func decl found in %q", src)
+	return nil
+}
+
+func TestFormatFuncDecl(t *testing.T) {
+	cases := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "plain",
+			src:  "func Foo(a int, b string) error { return nil }",
+			want: "func Foo(a int, b string) error",
+		},
+		{
+			name: "pointer receiver",
+			src:  "func (s *Set) Add(v int) { }",
+			want: "func (s *Set) Add(v int)",
+		},
+		{
+			name: "multiple results",
+			src:  "func Foo() (int, error) { return 0, nil }",
+			want: "func Foo() (int, error)",
+		},
+		{
+			name: "bidirectional channel",
+			src:  "func Foo(c chan int) { }",
+			want: "func Foo(c chan int)",
+		},
+		{
+			name: "receive-only channel",
+			src:  "func Foo(c <-chan int) { }",
+			want: "func Foo(c <-chan int)",
+		},
+		{
+			name: "send-only channel",
+			src:  "func Foo(c chan<- int) { }",
+			want: "func Foo(c chan<- int)",
+		},
+		{
+			name: "anonymous empty interface",
+			src:  "func Foo(v interface{}) { }",
+			want: "func Foo(v interface{})",
+		},
+		{
+			name: "anonymous interface with method",
+			src:  "func Foo(v interface{ Read(p []byte) (int, error) }) { }",
+			want: "func Foo(v interface { Read(p []byte) (int, error) })",
+		},
+		{
+			name: "anonymous struct",
+			src:  "func Foo(v struct{ X int }) { }",
+			want: "func Foo(v struct { X int })",
+		},
+		{
+			name: "generic function",
+			src:  "func Map[T, U any](s []T, f func(T) U) []U { return nil }",
+			want: "func Map[T, U any](s []T, f func(T) U) []U",
+		},
+		{
+			name: "generic receiver",
+			src:  "func (s *Set[T]) Add(v T) { }",
+			want: "func (s *Set[T]) Add(v T)",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			decl := parseFuncDecl(t, c.src)
+			if got := FormatFuncDecl(decl); got != c.want {
+				t.Errorf("FormatFuncDecl(%q) = %q, want %q", c.src, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Command go-list-func prints the exported functions and methods declared
+// in the given packages.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/AkihiroSuda/go-list-func/analyzer"
+	"github.com/AkihiroSuda/go-list-func/record"
+)
+
+func main() {
+	var buildTags string
+	var includeTests bool
+	var verbose bool
+	var format string
+	var includeInterfaces bool
+	var includePromoted bool
+	flag.StringVar(&buildTags, "tags", "", "build tags")
+	flag.BoolVar(&includeTests, "include-tests", false, "include tests")
+	flag.BoolVar(&verbose, "verbose", false, "verbose (shorthand for -format=verbose)")
+	flag.StringVar(&format, "format", "plain", "output format: plain, verbose, json, ndjson, godoc")
+	flag.BoolVar(&includeInterfaces, "include-interfaces", false, "include interface method sets")
+	flag.BoolVar(&includePromoted, "include-promoted", false, "include methods promoted from embedded fields")
+	flag.Parse()
+	if verbose && format == "plain" {
+		format = "verbose"
+	}
+	tags := parseBuildTags(buildTags)
+	pkgs, err := loadPackages(tags, flag.Args(), includeTests)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	printer, err := record.NewPrinter(format, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	if err := printFuncsInPackages(pkgs, tags, printer); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	if includeInterfaces || includePromoted {
+		if err := printMethodSets(pkgs, includeInterfaces, includePromoted, tags, printer); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+	if err := printer.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func parseBuildTags(tags string) []string {
+	if tags == "" {
+		return nil
+	}
+	var result []string
+	split := strings.Split(tags, ",")
+	for _, s := range split {
+		result = append(result, strings.TrimSpace(s))
+	}
+	return result
+}
+
+func loadPackages(tags, args []string, includeTests bool) ([]*packages.Package, error) {
+	conf := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedDeps,
+		Tests: includeTests,
+	}
+	if len(tags) > 0 {
+		conf.BuildFlags = []string{"-tags", strings.Join(tags, ",")}
+	}
+	pkgs, err := packages.Load(conf, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			return nil, e
+		}
+	}
+	return pkgs, nil
+}
+
+func printFuncsInPackages(pkgs []*packages.Package, tags []string, printer record.Printer) error {
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			if err := printFuncsInFile(pkg, file, tags, printer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func printFuncsInFile(pkg *packages.Package, file *ast.File, tags []string, printer record.Printer) error {
+	for _, xdecl := range file.Decls {
+		decl, ok := xdecl.(*ast.FuncDecl)
+		if !ok || !analyzer.Exported(decl) {
+			continue
+		}
+		if err := printer.Print(newFuncRecord(pkg, decl, tags)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newFuncRecord(pkg *packages.Package, decl *ast.FuncDecl, tags []string) record.FuncRecord {
+	pos := pkg.Fset.Position(decl.Pos())
+	rec := record.FuncRecord{
+		Package:   pkg.PkgPath,
+		File:      pos.Filename,
+		Line:      pos.Line,
+		Name:      decl.Name.Name,
+		Params:    paramsOf(decl.Type.Params),
+		Results:   paramsOf(decl.Type.Results),
+		Doc:       doc.Synopsis(decl.Doc.Text()),
+		BuildTags: tags,
+	}
+	if decl.Recv != nil && len(decl.Recv.List) == 1 {
+		recvType := decl.Recv.List[0].Type
+		if star, ok := recvType.(*ast.StarExpr); ok {
+			rec.RecvPointer = true
+			recvType = star.X
+		}
+		rec.Receiver = analyzer.FormatType(recvType)
+	}
+	return rec
+}
+
+func paramsOf(fields *ast.FieldList) []record.Param {
+	if fields == nil {
+		return nil
+	}
+	var params []record.Param
+	for _, field := range fields.List {
+		typ := analyzer.FormatType(field.Type)
+		if len(field.Names) == 0 {
+			params = append(params, record.Param{Type: typ})
+			continue
+		}
+		for _, name := range field.Names {
+			params = append(params, record.Param{Name: name.Name, Type: typ})
+		}
+	}
+	return params
+}
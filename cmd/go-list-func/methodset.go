@@ -0,0 +1,116 @@
+package main
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/AkihiroSuda/go-list-func/record"
+)
+
+// printMethodSets reports the method sets of every exported named type in
+// pkgs, using the type-checker's view of the package rather than a raw
+// AST walk. This is how embedded/promoted methods and interface method
+// declarations are discovered: neither has a corresponding *ast.FuncDecl
+// for printFuncsInFile to find.
+//
+// includeInterfaces reports interface-declared methods; includePromoted
+// reports methods promoted from embedded fields. Directly declared
+// struct/pointer methods are skipped, since printFuncsInFile already
+// reported those from the AST.
+func printMethodSets(pkgs []*packages.Package, includeInterfaces, includePromoted bool, tags []string, printer record.Printer) error {
+	for _, pkg := range pkgs {
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			tn, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || !tn.Exported() {
+				continue
+			}
+			named, ok := tn.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+			_, isInterface := named.Underlying().(*types.Interface)
+			if isInterface && !includeInterfaces {
+				continue
+			}
+			if err := printMethodSet(pkg, named, isInterface, includePromoted, tags, printer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func printMethodSet(pkg *packages.Package, named *types.Named, isInterface, includePromoted bool, tags []string, printer record.Printer) error {
+	mset := types.NewMethodSet(named)
+	if !isInterface {
+		mset = types.NewMethodSet(types.NewPointer(named))
+	}
+	// types.Selection.Index() only reflects embedding depth for structs;
+	// every interface method selection has len(Index()) == 1 regardless of
+	// how deep the embedding chain is. So for interfaces, "promoted" is
+	// instead decided by comparing against the interface's own explicitly
+	// declared methods (ExplicitMethod excludes anything inherited from an
+	// embedded interface).
+	var explicit map[string]bool
+	if isInterface {
+		iface := named.Underlying().(*types.Interface)
+		explicit = make(map[string]bool, iface.NumExplicitMethods())
+		for i := 0; i < iface.NumExplicitMethods(); i++ {
+			explicit[iface.ExplicitMethod(i).Name()] = true
+		}
+	}
+	for i := 0; i < mset.Len(); i++ {
+		sel := mset.At(i)
+		fn, ok := sel.Obj().(*types.Func)
+		if !ok || !fn.Exported() {
+			continue
+		}
+		promoted := len(sel.Index()) > 1
+		if isInterface {
+			promoted = !explicit[fn.Name()]
+		}
+		if promoted && !includePromoted {
+			continue
+		}
+		if !isInterface && !promoted {
+			// directly declared: printFuncsInFile already reported it from the AST.
+			continue
+		}
+		if err := printer.Print(newMethodRecord(pkg, named, fn, tags)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newMethodRecord(pkg *packages.Package, named *types.Named, fn *types.Func, tags []string) record.FuncRecord {
+	pos := pkg.Fset.Position(fn.Pos())
+	sig := fn.Type().(*types.Signature)
+	qualifier := types.RelativeTo(pkg.Types)
+	_, recvPointer := sig.Recv().Type().(*types.Pointer)
+	return record.FuncRecord{
+		Package:     pkg.PkgPath,
+		File:        pos.Filename,
+		Line:        pos.Line,
+		Name:        fn.Name(),
+		Receiver:    named.Obj().Name(),
+		RecvPointer: recvPointer,
+		Params:      tupleParams(sig.Params(), qualifier),
+		Results:     tupleParams(sig.Results(), qualifier),
+		BuildTags:   tags,
+	}
+}
+
+func tupleParams(tuple *types.Tuple, qualifier types.Qualifier) []record.Param {
+	if tuple == nil {
+		return nil
+	}
+	params := make([]record.Param, tuple.Len())
+	for i := 0; i < tuple.Len(); i++ {
+		v := tuple.At(i)
+		params[i] = record.Param{Name: v.Name(), Type: types.TypeString(v.Type(), qualifier)}
+	}
+	return params
+}
@@ -0,0 +1,14 @@
+// Command listfunc runs the listfunc analysis as a standalone checker,
+// suitable for `go vet -vettool=$(which listfunc)`, inclusion in a
+// multichecker pipeline, or `-json` consumption.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/AkihiroSuda/go-list-func/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
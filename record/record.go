@@ -0,0 +1,170 @@
+// Package record defines the structured representation of an exported
+// function or method declaration, and the Printer interface used to
+// render it in the output format requested on the command line.
+package record
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Param is a single parameter or result, e.g. "s []T" or the unnamed
+// result type "error".
+type Param struct {
+	Name string `json:"name,omitempty"`
+	Type string `json:"type"`
+}
+
+func (p Param) String() string {
+	if p.Name == "" {
+		return p.Type
+	}
+	return p.Name + " " + p.Type
+}
+
+// FuncRecord is the structured representation of a single exported
+// function or method declaration, carrying everything a downstream
+// consumer (an IDE, a code generator, a coverage differ) needs without
+// re-parsing the source.
+type FuncRecord struct {
+	Package     string   `json:"package"`
+	File        string   `json:"file"`
+	Line        int      `json:"line"`
+	Name        string   `json:"name"`
+	Receiver    string   `json:"receiver,omitempty"`
+	RecvPointer bool     `json:"recvPointer,omitempty"`
+	Params      []Param  `json:"params,omitempty"`
+	Results     []Param  `json:"results,omitempty"`
+	Doc         string   `json:"doc,omitempty"`
+	BuildTags   []string `json:"buildTags,omitempty"`
+}
+
+func joinParams(params []Param) string {
+	s := make([]string, len(params))
+	for i, p := range params {
+		s[i] = p.String()
+	}
+	return strings.Join(s, ", ")
+}
+
+// Signature renders the record's signature as
+// "func (Receiver) Name(params) results", with "*Receiver" when RecvPointer
+// is set. The receiver's variable name is not part of a FuncRecord, so only
+// the receiver type is shown.
+func (r FuncRecord) Signature() string {
+	s := "func "
+	if r.Receiver != "" {
+		star := ""
+		if r.RecvPointer {
+			star = "*"
+		}
+		s += fmt.Sprintf("(%s%s) ", star, r.Receiver)
+	}
+	s += fmt.Sprintf("%s(%s)", r.Name, joinParams(r.Params))
+	switch len(r.Results) {
+	case 0:
+	case 1:
+		s += " " + r.Results[0].String()
+	default:
+		s += " (" + joinParams(r.Results) + ")"
+	}
+	return s
+}
+
+// Printer renders FuncRecords to an io.Writer in a specific output
+// format. Print is called once per record; printers that need a wrapping
+// structure (e.g. a single JSON array) emit it in Close.
+type Printer interface {
+	Print(rec FuncRecord) error
+	Close() error
+}
+
+// NewPrinter returns the Printer for the named format: "plain", "verbose",
+// "json", "ndjson", or "godoc".
+func NewPrinter(format string, w io.Writer) (Printer, error) {
+	switch format {
+	case "", "plain":
+		return &plainPrinter{w: w}, nil
+	case "verbose":
+		return &verbosePrinter{w: w}, nil
+	case "json":
+		return &jsonPrinter{w: w}, nil
+	case "ndjson":
+		return &ndjsonPrinter{w: w}, nil
+	case "godoc":
+		return &godocPrinter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+type plainPrinter struct{ w io.Writer }
+
+func (p *plainPrinter) Print(rec FuncRecord) error {
+	_, err := fmt.Fprintln(p.w, rec.Name)
+	return err
+}
+
+func (p *plainPrinter) Close() error { return nil }
+
+type verbosePrinter struct{ w io.Writer }
+
+func (p *verbosePrinter) Print(rec FuncRecord) error {
+	_, err := fmt.Fprintln(p.w, rec.Signature())
+	return err
+}
+
+func (p *verbosePrinter) Close() error { return nil }
+
+// jsonPrinter buffers every record and emits a single JSON array on
+// Close, so the output is one well-formed JSON document.
+type jsonPrinter struct {
+	w       io.Writer
+	records []FuncRecord
+}
+
+func (p *jsonPrinter) Print(rec FuncRecord) error {
+	p.records = append(p.records, rec)
+	return nil
+}
+
+func (p *jsonPrinter) Close() error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	if p.records == nil {
+		return enc.Encode([]FuncRecord{})
+	}
+	return enc.Encode(p.records)
+}
+
+type ndjsonPrinter struct{ w io.Writer }
+
+func (p *ndjsonPrinter) Print(rec FuncRecord) error {
+	return json.NewEncoder(p.w).Encode(rec)
+}
+
+func (p *ndjsonPrinter) Close() error { return nil }
+
+// godocPrinter renders records in the terse one-paragraph style used by
+// `go doc`: the signature, followed by the doc comment indented four
+// spaces.
+type godocPrinter struct{ w io.Writer }
+
+func (p *godocPrinter) Print(rec FuncRecord) error {
+	if _, err := fmt.Fprintln(p.w, rec.Signature()); err != nil {
+		return err
+	}
+	for _, line := range strings.Split(strings.TrimRight(rec.Doc, "\n"), "\n") {
+		if rec.Doc == "" {
+			break
+		}
+		if _, err := fmt.Fprintln(p.w, "    "+line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *godocPrinter) Close() error { return nil }